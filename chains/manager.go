@@ -190,6 +190,9 @@ type ManagerConfig struct {
 	ResourceTracker timetracker.ResourceTracker
 
 	StateSyncBeacons []ids.NodeID
+
+	// Minimum time between two rebuilds of the last state summary
+	StateSyncSummaryRefreshInterval time.Duration
 }
 
 type manager struct {
@@ -781,8 +784,20 @@ func (m *manager) createSnowmanChain(
 		return nil, fmt.Errorf("error while fetching chain config: %w", err)
 	}
 
+	// State sync beacons are configured for every chain, so warn about the
+	// chains that won't be able to state sync.
+	if len(m.StateSyncBeacons) != 0 {
+		if _, ok := vm.(block.StateSyncableVM); !ok {
+			ctx.Log.Warn("state sync beacons are configured but the VM doesn't support state sync")
+		} else if _, ok := vm.(block.HeightIndexedChainVM); !ok {
+			// State summaries are built by resolving proposervm blocks through
+			// the height index, so without it state sync is disabled.
+			ctx.Log.Warn("state sync beacons are configured but the VM doesn't support height indexing, required by state sync")
+		}
+	}
+
 	// enable ProposerVM on this VM
-	vm = proposervm.New(vm, m.ApricotPhase4Time, m.ApricotPhase4MinPChainHeight)
+	vm = proposervm.New(vm, m.ApricotPhase4Time, m.ApricotPhase4MinPChainHeight, m.StateSyncSummaryRefreshInterval)
 
 	if m.MeterVMEnabled {
 		vm = metervm.NewBlockVM(vm)
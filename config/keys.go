@@ -58,6 +58,7 @@ const (
 	APIAuthPasswordFileKey                             = "api-auth-password-file"
 	StateSyncIPsKey                                    = "state-sync-ips"
 	StateSyncIDsKey                                    = "state-sync-ids"
+	StateSyncSummaryRefreshIntervalKey                 = "state-sync-summary-refresh-interval"
 	BootstrapIPsKey                                    = "bootstrap-ips"
 	BootstrapIDsKey                                    = "bootstrap-ids"
 	StakingPortKey                                     = "staking-port"
@@ -91,6 +91,10 @@ type StakingConfig struct {
 type StateSyncConfig struct {
 	StateSyncIDs []ids.NodeID `json:"stateSyncIDs"`
 	StateSyncIPs []ips.IPPort `json:"stateSyncIPs"`
+
+	// StateSyncSummaryRefreshInterval is the minimum time between two rebuilds
+	// of the last state summary served to peers
+	StateSyncSummaryRefreshInterval time.Duration `json:"stateSyncSummaryRefreshInterval"`
 }
 
 type BootstrapConfig struct {
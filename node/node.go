@@ -700,6 +700,7 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		ApricotPhase4MinPChainHeight:            version.GetApricotPhase4MinPChainHeight(n.Config.NetworkID),
 		ResourceTracker:                         n.resourceTracker,
 		StateSyncBeacons:                        n.Config.StateSyncIDs,
+		StateSyncSummaryRefreshInterval:         n.Config.StateSyncSummaryRefreshInterval,
 	})
 
 	// Notify the API server when new chains are created
@@ -31,11 +31,11 @@ const (
 )
 
 var (
-	errBadLength      = errors.New("packer has insufficient length for input")
-	errNegativeOffset = errors.New("negative offset")
-	errInvalidInput   = errors.New("input does not match expected format")
-	errBadType        = errors.New("wrong type passed")
-	errBadBool        = errors.New("unexpected value when unpacking bool")
+	ErrInsufficientLength = errors.New("packer has insufficient length for input")
+	errNegativeOffset     = errors.New("negative offset")
+	errInvalidInput       = errors.New("input does not match expected format")
+	errBadType            = errors.New("wrong type passed")
+	errBadBool            = errors.New("unexpected value when unpacking bool")
 )
 
 // Packer packs and unpacks a byte array from/to standard values
@@ -59,7 +59,7 @@ func (p *Packer) CheckSpace(bytes int) {
 	case bytes < 0:
 		p.Add(errInvalidInput)
 	case len(p.Bytes)-p.Offset < bytes:
-		p.Add(errBadLength)
+		p.Add(ErrInsufficientLength)
 	}
 }
 
@@ -73,7 +73,7 @@ func (p *Packer) Expand(bytes int) {
 	case neededSize <= len(p.Bytes): // Byte slice has sufficient length already
 		return
 	case neededSize > p.MaxSize: // Lengthening the byte slice would cause it to grow too large
-		p.Err = errBadLength
+		p.Err = ErrInsufficientLength
 		return
 	case neededSize <= cap(p.Bytes): // Byte slice has sufficient capacity to lengthen it without mem alloc
 		p.Bytes = p.Bytes[:neededSize]
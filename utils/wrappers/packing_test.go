@@ -39,13 +39,13 @@ func TestPackerCheckSpace(t *testing.T) {
 	p = Packer{Bytes: []byte{0x01}, Offset: 1}
 	p.CheckSpace(1)
 	if !p.Errored() {
-		t.Fatal("Expected errBadLength")
+		t.Fatal("Expected ErrInsufficientLength")
 	}
 
 	p = Packer{Bytes: []byte{0x01}, Offset: 2}
 	p.CheckSpace(0)
 	if !p.Errored() {
-		t.Fatal("Expected errBadLength, due to out of bounds offset")
+		t.Fatal("Expected ErrInsufficientLength, due to out of bounds offset")
 	}
 }
 
@@ -875,7 +875,7 @@ func initTestRemoteProposerVM(
 		}
 	}
 
-	proVM := New(coreVM, proBlkStartTime, 0)
+	proVM := New(coreVM, proBlkStartTime, 0, 0)
 
 	valState := &validators.TestState{
 		T: t,
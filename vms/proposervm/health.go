@@ -0,0 +1,26 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/snow"
+)
+
+var errStateSyncing = errors.New("chain is state syncing")
+
+// HealthCheck reports the inner VM's health. While the chain is state syncing,
+// the VM is reported unhealthy as it can't serve queries yet.
+func (vm *VM) HealthCheck() (interface{}, error) {
+	details, err := vm.ChainVM.HealthCheck()
+	if vm.consensusState != snow.StateSyncing {
+		return details, err
+	}
+	if err != nil {
+		return details, fmt.Errorf("%w, inner VM is unhealthy: %s", errStateSyncing, err)
+	}
+	return details, errStateSyncing
+}
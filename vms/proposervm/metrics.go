@@ -0,0 +1,81 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/metric"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	parseFailureInnerSummary   = "inner_summary"
+	parseFailureBlock          = "block"
+	parseFailureHeightMismatch = "height_mismatch"
+	parseFailureWrongChain     = "wrong_chain"
+)
+
+type stateSyncMetrics struct {
+	summariesServed, summariesAccepted prometheus.Counter
+	summaryParseFailures               *prometheus.CounterVec
+	lastSummaryHeight                  prometheus.Gauge
+
+	// time spent looking up the fork height and the height index while
+	// building a summary, split on whether the fork height was found. Fetching
+	// the summary block is not included.
+	summaryLookup, summaryLookupForkNotFound metric.Averager
+}
+
+func (m *stateSyncMetrics) Initialize(
+	namespace string,
+	registerer prometheus.Registerer,
+) error {
+	m.summariesServed = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "state_summaries_served",
+		Help:      "Number of state summaries returned by GetStateSummary",
+	})
+	m.summariesAccepted = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "state_summaries_accepted",
+		Help:      "Number of state summaries accepted for syncing",
+	})
+	m.summaryParseFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "state_summary_parse_failures",
+			Help:      "Number of state summaries that failed parsing, by failure reason",
+		},
+		[]string{"reason"},
+	)
+	m.lastSummaryHeight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "last_state_summary_height",
+		Help:      "Height of the last state summary returned by GetLastStateSummary",
+	})
+
+	errs := wrappers.Errs{}
+	m.summaryLookup = metric.NewAveragerWithErrs(
+		namespace,
+		"state_summary_lookup",
+		"time (in ns) of the fork height and height index lookups of a state summary",
+		registerer,
+		&errs,
+	)
+	m.summaryLookupForkNotFound = metric.NewAveragerWithErrs(
+		namespace,
+		"state_summary_lookup_fork_not_found",
+		"time (in ns) of the fork height lookup of a state summary with no fork height",
+		registerer,
+		&errs,
+	)
+	errs.Add(
+		registerer.Register(m.summariesServed),
+		registerer.Register(m.summariesAccepted),
+		registerer.Register(m.summaryParseFailures),
+		registerer.Register(m.lastSummaryHeight),
+	)
+	return errs.Err
+}
@@ -33,6 +33,8 @@ func (b *postForkBlock) acceptOuterBlk() error {
 	b.status = choices.Accepted
 	b.vm.lastAcceptedTime = b.Timestamp()
 	b.vm.lastAcceptedHeight = b.Height()
+	// the last state summary may advance with this block
+	b.vm.lastStateSummary = nil
 
 	blkID := b.ID()
 	delete(b.vm.verifiedBlocks, blkID)
@@ -39,6 +39,8 @@ func (b *postForkOption) acceptOuterBlk() error {
 	// Update in-memory references
 	b.status = choices.Accepted
 	b.vm.lastAcceptedHeight = b.Height()
+	// the last state summary may advance with this block
+	b.vm.lastStateSummary = nil
 
 	blkID := b.ID()
 	delete(b.vm.verifiedBlocks, blkID)
@@ -59,5 +59,12 @@ func (s *stateSummary) Accept() (bool, error) {
 	// innerSummary.Accept may fail with the proposerVM block and index already
 	// updated. The error would be treated as fatal and the chain would then be
 	// repaired upon the VM restart.
-	return s.innerSummary.Accept()
+	accepted, err := s.innerSummary.Accept()
+	if err != nil {
+		return false, err
+	}
+	if accepted {
+		s.vm.stateSyncMetrics.summariesAccepted.Inc()
+	}
+	return accepted, nil
 }
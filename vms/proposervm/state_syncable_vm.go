@@ -4,15 +4,29 @@
 package proposervm
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/vms/proposervm/summary"
+
+	statelessblock "github.com/ava-labs/avalanchego/vms/proposervm/block"
+)
+
+var (
+	errSummaryHeightMismatch = errors.New("summary height does not match its block height")
+	errEmptySummaryBlockID   = errors.New("height index returned an empty proposervm block ID")
+	errSelfTestMismatch      = errors.New("last state summary differs from the state summary at its height")
+	errWrongChainSummary     = errors.New("summary block was not signed for this chain")
 )
 
 func (vm *VM) StateSyncEnabled() (bool, error) {
 	if vm.ssVM == nil {
+		if vm.ChainVM == nil {
+			return false, errNilChainVM
+		}
 		return false, nil
 	}
 
@@ -25,9 +39,18 @@ func (vm *VM) StateSyncEnabled() (bool, error) {
 	return vm.ssVM.StateSyncEnabled()
 }
 
+// stateSyncUnavailableErr returns the error reported by the state sync methods
+// when there is no inner StateSyncableVM to serve them.
+func (vm *VM) stateSyncUnavailableErr() error {
+	if vm.ChainVM == nil {
+		return errNilChainVM
+	}
+	return block.ErrStateSyncableVMNotImplemented
+}
+
 func (vm *VM) GetOngoingSyncStateSummary() (block.StateSummary, error) {
 	if vm.ssVM == nil {
-		return nil, block.ErrStateSyncableVMNotImplemented
+		return nil, vm.stateSyncUnavailableErr()
 	}
 
 	innerSummary, err := vm.ssVM.GetOngoingSyncStateSummary()
@@ -40,7 +63,14 @@ func (vm *VM) GetOngoingSyncStateSummary() (block.StateSummary, error) {
 
 func (vm *VM) GetLastStateSummary() (block.StateSummary, error) {
 	if vm.ssVM == nil {
-		return nil, block.ErrStateSyncableVMNotImplemented
+		return nil, vm.stateSyncUnavailableErr()
+	}
+
+	// Serve a recently built summary without querying the inner VM, so that
+	// repeated requests from peers don't trigger a rebuild each.
+	if vm.lastStateSummary != nil && vm.VerifyHeightIndex() == nil &&
+		vm.Clock.Time().Sub(vm.lastStateSummaryTime) < vm.lastStateSummaryInterval {
+		return vm.lastStateSummary, nil
 	}
 
 	// Extract inner vm's last state summary
@@ -49,30 +79,76 @@ func (vm *VM) GetLastStateSummary() (block.StateSummary, error) {
 		return nil, err // including database.ErrNotFound case
 	}
 
-	return vm.buildStateSummary(innerSummary)
+	// Serve the cached summary if it wraps the same inner summary. The height
+	// index is verified first so that no summary is served while repairing it.
+	if vm.lastStateSummary != nil && vm.VerifyHeightIndex() == nil &&
+		vm.lastStateSummary.innerSummary.ID() == innerSummary.ID() {
+		return vm.lastStateSummary, nil
+	}
+
+	summary, err := vm.buildStateSummary(innerSummary)
+	if err != nil {
+		return nil, err
+	}
+	if proSummary, ok := summary.(*stateSummary); ok {
+		vm.lastStateSummary = proSummary
+		vm.lastStateSummaryTime = vm.Clock.Time()
+	}
+	vm.stateSyncMetrics.lastSummaryHeight.Set(float64(summary.Height()))
+	return summary, nil
 }
 
 // Note: it's important that ParseStateSummary do not use any index or state
 // to allow summaries being parsed also by freshly started node with no previous state.
 func (vm *VM) ParseStateSummary(summaryBytes []byte) (block.StateSummary, error) {
 	if vm.ssVM == nil {
-		return nil, block.ErrStateSyncableVMNotImplemented
+		return nil, vm.stateSyncUnavailableErr()
 	}
 
 	statelessSummary, err := summary.Parse(summaryBytes)
 	if err != nil {
-		// it may be a preFork summary
-		return vm.ssVM.ParseStateSummary(summaryBytes)
+		// it may be a preFork summary. Note that truncated bytes may be one
+		// too, as the inner VM may prefix its summaries with the same codec
+		// version.
+		innerSummary, innerErr := vm.ssVM.ParseStateSummary(summaryBytes)
+		if innerErr != nil {
+			return nil, &summaryParseError{
+				postForkErr: err,
+				preForkErr:  innerErr,
+			}
+		}
+		return innerSummary, nil
 	}
 
 	innerSummary, err := vm.ssVM.ParseStateSummary(statelessSummary.InnerSummaryBytes())
 	if err != nil {
+		vm.stateSyncMetrics.summaryParseFailures.WithLabelValues(parseFailureInnerSummary).Inc()
 		return nil, fmt.Errorf("could not parse inner summary due to: %w", err)
 	}
 	block, err := vm.parsePostForkBlock(statelessSummary.BlockBytes())
 	if err != nil {
+		vm.stateSyncMetrics.summaryParseFailures.WithLabelValues(parseFailureBlock).Inc()
 		return nil, fmt.Errorf("could not parse proposervm block bytes from summary due to: %w", err)
 	}
+	// The proposer signature commits to the chain ID. Checking it reports
+	// blocks of another chain or network explicitly.
+	if signedBlk, ok := block.getStatelessBlk().(statelessblock.SignedBlock); ok && signedBlk.Proposer() != ids.EmptyNodeID {
+		if err := signedBlk.Verify(true, vm.ctx.ChainID); err != nil {
+			vm.stateSyncMetrics.summaryParseFailures.WithLabelValues(parseFailureWrongChain).Inc()
+			return nil, fmt.Errorf("%w: block %s, chain %s: %s", errWrongChainSummary, block.ID(), vm.ctx.ChainID, err)
+		}
+	}
+	if blockHeight, summaryHeight := block.Height(), innerSummary.Height(); blockHeight != summaryHeight {
+		vm.stateSyncMetrics.summaryParseFailures.WithLabelValues(parseFailureHeightMismatch).Inc()
+		return nil, fmt.Errorf("%w: block %s has height %d, inner summary %s has height %d",
+			errSummaryHeightMismatch, block.ID(), blockHeight, innerSummary.ID(), summaryHeight)
+	}
+
+	vm.ctx.Log.Debug(
+		"parsed post-fork summary, ID: %s, height: %d",
+		statelessSummary.ID(),
+		innerSummary.Height(),
+	)
 
 	return &stateSummary{
 		StateSummary: statelessSummary,
@@ -82,19 +158,214 @@ func (vm *VM) ParseStateSummary(summaryBytes []byte) (block.StateSummary, error)
 	}, nil
 }
 
+// summaryParseError is returned when summary bytes can be parsed neither as a
+// post-fork nor as a pre-fork summary. Both causes can be matched by errors.Is.
+type summaryParseError struct {
+	postForkErr, preForkErr error
+}
+
+func (e *summaryParseError) Error() string {
+	return fmt.Sprintf("could not parse summary as post-fork (%s) nor as pre-fork: %s", e.postForkErr, e.preForkErr)
+}
+
+func (e *summaryParseError) Is(target error) bool {
+	return errors.Is(e.postForkErr, target) || errors.Is(e.preForkErr, target)
+}
+
 func (vm *VM) GetStateSummary(height uint64) (block.StateSummary, error) {
+	summary, err := vm.getStateSummary(height)
+	if err != nil {
+		return nil, err
+	}
+	vm.stateSyncMetrics.summariesServed.Inc()
+	return summary, nil
+}
+
+// getStateSummary builds the state summary at [height]. Differently from
+// GetStateSummary, it is not reported as served.
+func (vm *VM) getStateSummary(height uint64) (block.StateSummary, error) {
 	if vm.ssVM == nil {
-		return nil, block.ErrStateSyncableVMNotImplemented
+		return nil, vm.stateSyncUnavailableErr()
 	}
 
 	innerSummary, err := vm.ssVM.GetStateSummary(height)
 	if err != nil {
 		return nil, err // including database.ErrNotFound case
 	}
-
 	return vm.buildStateSummary(innerSummary)
 }
 
+// GetStateSummaryWithBlockID returns the state summary at [height] along with
+// the ID of the proposervm block it refers to. Pre-fork summaries do not refer
+// to any proposervm block, so ids.Empty is returned with them.
+func (vm *VM) GetStateSummaryWithBlockID(height uint64) (block.StateSummary, ids.ID, error) {
+	summary, err := vm.getStateSummary(height)
+	if err != nil {
+		return nil, ids.Empty, err
+	}
+	proSummary, ok := summary.(*stateSummary)
+	if !ok {
+		return summary, ids.Empty, nil
+	}
+	return summary, proSummary.block.ID(), nil
+}
+
+// GetStateSummaries retrieves the state summaries generated at [heights].
+//
+// Summaries that are not available are omitted from the returned slice. If any
+// is missing, an error wrapping database.ErrNotFound and listing the missing
+// heights is returned along with the available summaries.
+func (vm *VM) GetStateSummaries(heights []uint64) ([]block.StateSummary, error) {
+	if vm.ssVM == nil {
+		return nil, vm.stateSyncUnavailableErr()
+	}
+
+	var (
+		summaries      = make([]block.StateSummary, 0, len(heights))
+		missingHeights []uint64
+	)
+	for _, height := range heights {
+		summary, err := vm.getStateSummary(height)
+		if err == database.ErrNotFound {
+			missingHeights = append(missingHeights, height)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, summary)
+	}
+
+	if len(missingHeights) != 0 {
+		return summaries, fmt.Errorf("%w: no state summaries at heights %v", database.ErrNotFound, missingHeights)
+	}
+	return summaries, nil
+}
+
+// HasStateSummary returns true if a state summary can be served at [height].
+// It resolves the summary block as GetStateSummary does, but only checks that
+// the block is stored, without parsing it nor building the summary.
+func (vm *VM) HasStateSummary(height uint64) (bool, error) {
+	if vm.ssVM == nil {
+		return false, vm.stateSyncUnavailableErr()
+	}
+
+	switch _, err := vm.ssVM.GetStateSummary(height); err {
+	case nil:
+	case database.ErrNotFound:
+		return false, nil
+	default:
+		return false, err
+	}
+
+	// if vm implements Snowman++, a block height index must be available
+	// to support state sync
+	if err := vm.VerifyHeightIndex(); err != nil {
+		return false, err
+	}
+
+	// don't hit the database if the VM is shutting down
+	if err := vm.context.Err(); err != nil {
+		return false, err
+	}
+
+	forkHeight, err := vm.GetForkHeight()
+	switch err {
+	case nil:
+		if height < forkHeight {
+			return true, nil
+		}
+	case database.ErrNotFound:
+		// fork has not been reached, the summary must be pre-fork
+		return true, nil
+	default:
+		return false, fmt.Errorf("could not retrieve fork height: %w", err)
+	}
+
+	blkID, err := vm.getSummaryBlockID(height)
+	switch err {
+	case nil:
+	case database.ErrNotFound:
+		return false, nil
+	default:
+		return false, err
+	}
+	switch _, _, err := vm.State.GetBlock(blkID); err {
+	case nil:
+		return true, nil
+	case database.ErrNotFound:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// CompareLastStateSummary parses [summaryBytes] and compares the resulting
+// summary with the local last state summary. If they differ, the returned
+// reason describes the first differing field.
+func (vm *VM) CompareLastStateSummary(summaryBytes []byte) (bool, string, error) {
+	localSummary, err := vm.GetLastStateSummary()
+	if err != nil {
+		return false, "", fmt.Errorf("could not retrieve local last state summary: %w", err)
+	}
+	otherSummary, err := vm.ParseStateSummary(summaryBytes)
+	if err != nil {
+		return false, "", err
+	}
+
+	equal, reason := compareStateSummaries(localSummary, otherSummary)
+	return equal, reason, nil
+}
+
+// StateSyncSelfTest checks that the last state summary can be retrieved again
+// by height and that both summaries match. It is a local diagnostic of the
+// state sync wiring and does not require any peer.
+func (vm *VM) StateSyncSelfTest() error {
+	lastSummary, err := vm.GetLastStateSummary()
+	if err != nil {
+		return fmt.Errorf("could not retrieve last state summary: %w", err)
+	}
+
+	height := lastSummary.Height()
+	summary, err := vm.getStateSummary(height)
+	if err != nil {
+		return fmt.Errorf("could not retrieve state summary at height %d: %w", height, err)
+	}
+
+	if equal, reason := compareStateSummaries(lastSummary, summary); !equal {
+		return fmt.Errorf("%w: %s", errSelfTestMismatch, reason)
+	}
+	return nil
+}
+
+func compareStateSummaries(local, other block.StateSummary) (bool, string) {
+	if localHeight, otherHeight := local.Height(), other.Height(); localHeight != otherHeight {
+		return false, fmt.Sprintf("height: local %d, other %d", localHeight, otherHeight)
+	}
+
+	localPostFork, localIsPostFork := local.(*stateSummary)
+	otherPostFork, otherIsPostFork := other.(*stateSummary)
+	if localIsPostFork != otherIsPostFork {
+		return false, fmt.Sprintf("post-fork: local %t, other %t", localIsPostFork, otherIsPostFork)
+	}
+	if localIsPostFork {
+		if localForkHeight, otherForkHeight := localPostFork.ForkHeight(), otherPostFork.ForkHeight(); localForkHeight != otherForkHeight {
+			return false, fmt.Sprintf("fork height: local %d, other %d", localForkHeight, otherForkHeight)
+		}
+		if localBlkID, otherBlkID := localPostFork.block.ID(), otherPostFork.block.ID(); localBlkID != otherBlkID {
+			return false, fmt.Sprintf("proposervm block ID: local %s, other %s", localBlkID, otherBlkID)
+		}
+		if localInnerID, otherInnerID := localPostFork.innerSummary.ID(), otherPostFork.innerSummary.ID(); localInnerID != otherInnerID {
+			return false, fmt.Sprintf("inner summary ID: local %s, other %s", localInnerID, otherInnerID)
+		}
+	}
+
+	if localID, otherID := local.ID(), other.ID(); localID != otherID {
+		return false, fmt.Sprintf("summary ID: local %s, other %s", localID, otherID)
+	}
+	return true, ""
+}
+
 // Note: building state summary requires a well formed height index.
 func (vm *VM) buildStateSummary(innerSummary block.StateSummary) (block.StateSummary, error) {
 	// if vm implements Snowman++, a block height index must be available
@@ -103,15 +374,28 @@ func (vm *VM) buildStateSummary(innerSummary block.StateSummary) (block.StateSum
 		return nil, fmt.Errorf("could not build state summary: %w", err)
 	}
 
+	// don't hit the database if the VM is shutting down
+	if err := vm.context.Err(); err != nil {
+		return nil, err
+	}
+	start := vm.Clock.Time()
 	forkHeight, err := vm.GetForkHeight()
 	switch err {
 	case nil:
 		if innerSummary.Height() < forkHeight {
+			vm.stateSyncMetrics.summaryLookup.Observe(float64(vm.Clock.Time().Sub(start)))
+			vm.ctx.Log.Debug(
+				"built pre-fork summary, ID: %s, height: %d, fork height: %d",
+				innerSummary.ID(),
+				innerSummary.Height(),
+				forkHeight,
+			)
 			return innerSummary, nil
 		}
 	case database.ErrNotFound:
 		// fork has not been reached since there is not fork height
 		// just return innerSummary
+		vm.stateSyncMetrics.summaryLookupForkNotFound.Observe(float64(vm.Clock.Time().Sub(start)))
 		vm.ctx.Log.Debug(
 			"built pre-fork summary, ID: %s, height: %d",
 			innerSummary.ID(),
@@ -119,18 +403,21 @@ func (vm *VM) buildStateSummary(innerSummary block.StateSummary) (block.StateSum
 		)
 		return innerSummary, nil
 	default:
-		return nil, err
+		return nil, fmt.Errorf("could not retrieve fork height: %w", err)
 	}
 
+	if err := vm.context.Err(); err != nil {
+		return nil, err
+	}
 	height := innerSummary.Height()
-	blkID, err := vm.GetBlockIDAtHeight(height)
+	blkID, err := vm.getSummaryBlockID(height)
+	vm.stateSyncMetrics.summaryLookup.Observe(float64(vm.Clock.Time().Sub(start)))
 	if err != nil {
-		vm.ctx.Log.Debug("failed to fetch proposervm block ID at height %d with %s", height, err)
 		return nil, err
 	}
-	block, err := vm.getPostForkBlock(blkID)
+	block, err := vm.getSummaryBlock(blkID, height)
 	if err != nil {
-		vm.ctx.Log.Warn("failed to fetch proposervm block %s at height %d with %s", blkID, height, err)
+		vm.ctx.Log.Warn("failed to fetch proposervm block %s at height %d for summary %s with %s", blkID, height, innerSummary.ID(), err)
 		return nil, err
 	}
 
@@ -140,9 +427,11 @@ func (vm *VM) buildStateSummary(innerSummary block.StateSummary) (block.StateSum
 	}
 
 	vm.ctx.Log.Debug(
-		"built post-fork summary, ID: %s, height: %d",
+		"built post-fork summary, ID: %s, height: %d, fork height: %d, proposervm block: %s",
 		statelessSummary.ID(),
+		height,
 		forkHeight,
+		block.ID(),
 	)
 	return &stateSummary{
 		StateSummary: statelessSummary,
@@ -151,3 +440,30 @@ func (vm *VM) buildStateSummary(innerSummary block.StateSummary) (block.StateSum
 		vm:           vm,
 	}, nil
 }
+
+// getSummaryBlockID returns the ID of the accepted proposervm block that a
+// post-fork summary at [height] wraps, as resolved through the height index.
+func (vm *VM) getSummaryBlockID(height uint64) (ids.ID, error) {
+	blkID, err := vm.GetBlockIDAtHeight(height)
+	if err != nil {
+		return ids.Empty, err
+	}
+	if blkID == ids.Empty {
+		return ids.Empty, fmt.Errorf("%w: at height %d", errEmptySummaryBlockID, height)
+	}
+	return blkID, nil
+}
+
+// getSummaryBlock returns the proposervm block [blkID], which the height index
+// resolved for a post-fork summary at [height].
+func (vm *VM) getSummaryBlock(blkID ids.ID, height uint64) (PostForkBlock, error) {
+	block, err := vm.getPostForkBlock(blkID)
+	if err != nil {
+		return nil, err
+	}
+	if blockHeight := block.Height(); blockHeight != height {
+		return nil, fmt.Errorf("%w: height index maps height %d to block %s, which has height %d",
+			errSummaryHeightMismatch, height, blkID, blockHeight)
+	}
+	return block, nil
+}
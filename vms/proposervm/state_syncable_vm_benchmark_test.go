@@ -0,0 +1,89 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+func BenchmarkGetStateSummary(b *testing.B) {
+	innerSummarySizes := []int{
+		1 * units.KiB,   // 1kb
+		16 * units.KiB,  // 16kb
+		256 * units.KiB, // 256kb
+		1 * units.MiB,   // 1mb
+	}
+	for _, size := range innerSummarySizes {
+		innerVM, vm := helperBuildStateSyncTestObjects(b)
+		reqHeight := uint64(1969)
+
+		innerSummary := &block.TestStateSummary{
+			IDV:     ids.ID{'s', 'u', 'm', 'm', 'a', 'r', 'y', 'I', 'D'},
+			HeightV: reqHeight,
+			BytesV:  make([]byte, size),
+		}
+		innerVM.GetStateSummaryF = func(uint64) (block.StateSummary, error) {
+			return innerSummary, nil
+		}
+
+		vm.hIndexer.MarkRepaired(true)
+		if err := vm.SetForkHeight(reqHeight - 1); err != nil {
+			b.Fatal(err)
+		}
+		helperStorePostForkBlock(b, innerVM, vm, reqHeight, vm.ctx.ChainID)
+
+		b.Run(fmt.Sprintf("%d bytes", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				if _, err := vm.GetStateSummary(reqHeight); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGetLastStateSummary(b *testing.B) {
+	innerVM, vm := helperBuildStateSyncTestObjects(b)
+	reqHeight := uint64(1969)
+
+	innerSummary := &block.TestStateSummary{
+		IDV:     ids.ID{'s', 'u', 'm', 'm', 'a', 'r', 'y', 'I', 'D'},
+		HeightV: reqHeight,
+		BytesV:  make([]byte, 16*units.KiB),
+	}
+	innerVM.GetLastStateSummaryF = func() (block.StateSummary, error) {
+		return innerSummary, nil
+	}
+
+	vm.hIndexer.MarkRepaired(true)
+	if err := vm.SetForkHeight(reqHeight - 1); err != nil {
+		b.Fatal(err)
+	}
+	helperStorePostForkBlock(b, innerVM, vm, reqHeight, vm.ctx.ChainID)
+
+	// cold calls drop the cached summary so that each one is built again
+	for _, cached := range []bool{false, true} {
+		name := "cold"
+		if cached {
+			name = "cached"
+		}
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				if !cached {
+					vm.lastStateSummary = nil
+				}
+				if _, err := vm.GetLastStateSummary(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -5,13 +5,18 @@ package proposervm
 
 import (
 	"bytes"
+	"context"
 	"crypto"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 
+	dto "github.com/prometheus/client_model/go"
+
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/manager"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
@@ -24,11 +29,12 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms/proposervm/state"
+	"github.com/ava-labs/avalanchego/vms/proposervm/summary"
 
 	statelessblock "github.com/ava-labs/avalanchego/vms/proposervm/block"
 )
 
-func stopHeightReindexing(t *testing.T, coreVM *fullVM, dbMan manager.Manager) {
+func stopHeightReindexing(t testing.TB, coreVM *fullVM, dbMan manager.Manager) {
 	rawDB := dbMan.Current().Database
 	prefixDB := prefixdb.New(dbPrefix, rawDB)
 	db := versiondb.New(prefixDB)
@@ -47,18 +53,27 @@ func stopHeightReindexing(t *testing.T, coreVM *fullVM, dbMan manager.Manager) {
 	coreVM.VerifyHeightIndexF = func() error { return nil }
 }
 
-func helperBuildStateSyncTestObjects(t *testing.T) (*fullVM, *VM) {
+func helperBuildStateSyncTestObjects(t testing.TB) (*fullVM, *VM) {
+	innerVM, vm, _ := helperBuildStateSyncTestObjectsWithMetrics(t)
+	return innerVM, vm
+}
+
+// helperBuildStateSyncTestObjectsWithMetrics also returns the gatherer the
+// proposervm registered its metrics on.
+func helperBuildStateSyncTestObjectsWithMetrics(t testing.TB) (*fullVM, *VM, prometheus.Gatherer) {
+	// unexpected inner VM calls fail tests, and return errors in benchmarks
+	testT, _ := t.(*testing.T)
 	innerVM := &fullVM{
 		TestVM: &block.TestVM{
 			TestVM: common.TestVM{
-				T: t,
+				T: testT,
 			},
 		},
 		TestHeightIndexedVM: &block.TestHeightIndexedVM{
-			T: t,
+			T: testT,
 		},
 		TestStateSyncableVM: &block.TestStateSyncableVM{
-			T: t,
+			T: testT,
 		},
 	}
 
@@ -86,18 +101,121 @@ func helperBuildStateSyncTestObjects(t *testing.T) (*fullVM, *VM) {
 	innerVM.GetBlockF = func(i ids.ID) (snowman.Block, error) { return innerGenesisBlk, nil }
 
 	// createVM
-	vm := New(innerVM, time.Time{}, uint64(0))
+	vm := New(innerVM, time.Time{}, uint64(0), time.Second)
 
 	ctx := snow.DefaultContextTest()
 	ctx.NodeID = ids.NodeIDFromCert(pTestCert.Leaf)
 	ctx.StakingCertLeaf = pTestCert.Leaf
 	ctx.StakingLeafSigner = pTestCert.PrivateKey.(crypto.Signer)
+	gatherer := ctx.Metrics
 
 	if err := vm.Initialize(ctx, dbManager, innerGenesisBlk.Bytes(), nil, nil, nil, nil, nil); err != nil {
 		t.Fatalf("failed to initialize proposerVM with %s", err)
 	}
 
-	return innerVM, vm
+	return innerVM, vm, gatherer
+}
+
+// helperStorePostForkBlock stores an accepted post fork block at [height],
+// signed for [chainID], and lets [innerVM] parse its inner block.
+func helperStorePostForkBlock(t testing.TB, innerVM *fullVM, vm *VM, height uint64, chainID ids.ID) *postForkBlock {
+	innerBlk := &snowman.TestBlock{
+		BytesV:     []byte(fmt.Sprintf("inner block %d", height)),
+		TimestampV: vm.Time(),
+		HeightV:    height,
+	}
+	parseInnerBlk := innerVM.ParseBlockF
+	innerVM.ParseBlockF = func(b []byte) (snowman.Block, error) {
+		if bytes.Equal(b, innerBlk.Bytes()) {
+			return innerBlk, nil
+		}
+		if parseInnerBlk != nil {
+			return parseInnerBlk(b)
+		}
+		return nil, errUnknownBlock
+	}
+
+	slb, err := statelessblock.Build(
+		vm.preferred,
+		innerBlk.Timestamp(),
+		100, // pChainHeight,
+		vm.ctx.StakingCertLeaf,
+		innerBlk.Bytes(),
+		chainID,
+		vm.ctx.StakingLeafSigner,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proBlk := &postForkBlock{
+		SignedBlock: slb,
+		postForkCommonComponents: postForkCommonComponents{
+			vm:       vm,
+			innerBlk: innerBlk,
+			status:   choices.Accepted,
+		},
+	}
+	if err := vm.storePostForkBlock(proBlk); err != nil {
+		t.Fatal(err)
+	}
+	return proBlk
+}
+
+// counterValue returns the current value of [counter]
+func counterValue(t *testing.T, counter prometheus.Counter) float64 {
+	metric := &dto.Metric{}
+	if err := counter.Write(metric); err != nil {
+		t.Fatal(err)
+	}
+	return metric.GetCounter().GetValue()
+}
+
+// gatheredValue returns the value of the [name] series gathered from
+// [gatherer], restricted to the metric with the given label values if any.
+func gatheredValue(t *testing.T, gatherer prometheus.Gatherer, name string, labels map[string]string) (float64, bool) {
+	families, err := gatherer.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if value, ok := labels[label.GetName()]; ok && value != label.GetValue() {
+					continue metrics
+				}
+			}
+			switch {
+			case metric.Counter != nil:
+				return metric.GetCounter().GetValue(), true
+			case metric.Gauge != nil:
+				return metric.GetGauge().GetValue(), true
+			}
+		}
+	}
+	return 0, false
+}
+
+// failingForkHeightState overrides GetForkHeight to return [err]
+type failingForkHeightState struct {
+	state.State
+	err error
+}
+
+func (s *failingForkHeightState) GetForkHeight() (uint64, error) {
+	return 0, s.err
+}
+
+// emptyBlockIDState overrides GetBlockIDAtHeight to return an empty ID
+type emptyBlockIDState struct {
+	state.State
+}
+
+func (*emptyBlockIDState) GetBlockIDAtHeight(uint64) (ids.ID, error) {
+	return ids.Empty, nil
 }
 
 func TestStateSyncEnabled(t *testing.T) {
@@ -119,6 +237,42 @@ func TestStateSyncEnabled(t *testing.T) {
 	assert.True(enabled)
 }
 
+func TestStateSyncNilInnerVM(t *testing.T) {
+	assert := assert.New(t)
+
+	vm := New(nil, time.Time{}, uint64(0), 0)
+
+	// state sync is reported as disabled rather than panicking, with an error
+	// telling it apart from an inner VM not implementing state sync
+	enabled, err := vm.StateSyncEnabled()
+	assert.ErrorIs(err, errNilChainVM)
+	assert.False(enabled)
+
+	_, err = vm.GetLastStateSummary()
+	assert.ErrorIs(err, errNilChainVM)
+
+	_, err = vm.ParseStateSummary(nil)
+	assert.ErrorIs(err, errNilChainVM)
+
+	_, err = vm.GetStateSummary(0)
+	assert.ErrorIs(err, errNilChainVM)
+
+	// an inner VM not implementing state sync is reported differently
+	// the VM can't be initialized without an inner VM
+	dbManager := manager.NewMemDB(version.DefaultVersion1_0_0)
+	err = vm.Initialize(snow.DefaultContextTest(), dbManager, nil, nil, nil, nil, nil, nil)
+	assert.ErrorIs(err, errNilChainVM)
+
+	// an inner VM not implementing state sync is reported differently
+	nonSyncableVM := New(&block.TestVM{}, time.Time{}, uint64(0), 0)
+	enabled, err = nonSyncableVM.StateSyncEnabled()
+	assert.NoError(err)
+	assert.False(enabled)
+
+	_, err = nonSyncableVM.GetLastStateSummary()
+	assert.ErrorIs(err, block.ErrStateSyncableVMNotImplemented)
+}
+
 func TestStateSyncGetOngoingSyncStateSummary(t *testing.T) {
 	assert := assert.New(t)
 
@@ -283,6 +437,265 @@ func TestStateSyncGetLastStateSummary(t *testing.T) {
 	assert.True(summary.Height() == innerSummary.Height())
 }
 
+func TestStateSyncGetLastStateSummaryCached(t *testing.T) {
+	assert := assert.New(t)
+
+	innerVM, vm := helperBuildStateSyncTestObjects(t)
+
+	innerSummary := &block.TestStateSummary{
+		IDV:     ids.ID{'s', 'u', 'm', 'm', 'a', 'r', 'y', 'I', 'D'},
+		HeightV: uint64(2022),
+		BytesV:  []byte{'i', 'n', 'n', 'e', 'r'},
+	}
+	innerVM.GetLastStateSummaryF = func() (block.StateSummary, error) {
+		return innerSummary, nil
+	}
+
+	vm.hIndexer.MarkRepaired(true)
+	assert.NoError(vm.SetForkHeight(innerSummary.Height() - 1))
+
+	// store post fork block associated with summary
+	helperStorePostForkBlock(t, innerVM, vm, innerSummary.Height(), vm.ctx.ChainID)
+	parseCalls := 0
+	parseInnerBlk := innerVM.ParseBlockF
+	innerVM.ParseBlockF = func(b []byte) (snowman.Block, error) {
+		parseCalls++
+		return parseInnerBlk(b)
+	}
+
+	now := time.Now()
+	vm.Clock.Set(now)
+
+	summary, err := vm.GetLastStateSummary()
+	assert.NoError(err)
+	assert.Equal(1, parseCalls)
+
+	// the same inner summary is served from cache
+	cachedSummary, err := vm.GetLastStateSummary()
+	assert.NoError(err)
+	assert.Equal(summary, cachedSummary)
+	assert.Equal(1, parseCalls)
+
+	// a new inner summary is rebuilt once the refresh interval elapsed
+	newInnerSummary := &block.TestStateSummary{
+		IDV:     ids.ID{'n', 'e', 'w', 'S', 'u', 'm', 'm', 'a', 'r', 'y', 'I', 'D'},
+		HeightV: innerSummary.Height(),
+		BytesV:  []byte{'n', 'e', 'w'},
+	}
+	innerVM.GetLastStateSummaryF = func() (block.StateSummary, error) {
+		return newInnerSummary, nil
+	}
+
+	// within the refresh interval the inner VM is not queried
+	cachedSummary, err = vm.GetLastStateSummary()
+	assert.NoError(err)
+	assert.Equal(summary, cachedSummary)
+	assert.Equal(1, parseCalls)
+
+	vm.Clock.Set(now.Add(vm.lastStateSummaryInterval))
+	newSummary, err := vm.GetLastStateSummary()
+	assert.NoError(err)
+	assert.NotEqual(summary.ID(), newSummary.ID())
+	assert.Equal(2, parseCalls)
+}
+
+func TestStateSyncGetLastStateSummaryInvalidatedOnAccept(t *testing.T) {
+	assert := assert.New(t)
+
+	innerVM, vm := helperBuildStateSyncTestObjects(t)
+	reqHeight := uint64(1969)
+
+	innerSummary := &block.TestStateSummary{
+		IDV:     ids.ID{'s', 'u', 'm', 'm', 'a', 'r', 'y', 'I', 'D'},
+		HeightV: reqHeight,
+		BytesV:  []byte{'i', 'n', 'n', 'e', 'r'},
+	}
+	innerVM.GetLastStateSummaryF = func() (block.StateSummary, error) {
+		return innerSummary, nil
+	}
+
+	vm.hIndexer.MarkRepaired(true)
+	assert.NoError(vm.SetForkHeight(innerSummary.Height() - 1))
+
+	// store post fork block associated with summary
+	helperStorePostForkBlock(t, innerVM, vm, reqHeight, vm.ctx.ChainID)
+
+	vm.Clock.Set(time.Now())
+	summary, err := vm.GetLastStateSummary()
+	assert.NoError(err)
+	assert.Equal(reqHeight, summary.Height())
+
+	// accepting a child block invalidates the cached summary, even within the
+	// refresh interval
+	childProBlk := helperStorePostForkBlock(t, innerVM, vm, reqHeight+1, vm.ctx.ChainID)
+	childProBlk.status = choices.Processing
+	assert.NoError(childProBlk.acceptOuterBlk())
+
+	childInnerSummary := &block.TestStateSummary{
+		IDV:     ids.ID{'c', 'h', 'i', 'l', 'd', 'I', 'D'},
+		HeightV: childProBlk.Height(),
+		BytesV:  []byte{'c', 'h', 'i', 'l', 'd'},
+	}
+	innerVM.GetLastStateSummaryF = func() (block.StateSummary, error) {
+		return childInnerSummary, nil
+	}
+
+	newSummary, err := vm.GetLastStateSummary()
+	assert.NoError(err)
+	assert.Equal(childProBlk.Height(), newSummary.Height())
+	assert.NotEqual(summary.ID(), newSummary.ID())
+}
+
+func TestStateSyncGetLastStateSummaryInvalidatedOnSetState(t *testing.T) {
+	assert := assert.New(t)
+
+	innerVM, vm := helperBuildStateSyncTestObjects(t)
+	reqHeight := uint64(1969)
+
+	innerSummary := &block.TestStateSummary{
+		IDV:     ids.ID{'s', 'u', 'm', 'm', 'a', 'r', 'y', 'I', 'D'},
+		HeightV: reqHeight,
+		BytesV:  []byte{'i', 'n', 'n', 'e', 'r'},
+	}
+	innerVM.GetLastStateSummaryF = func() (block.StateSummary, error) {
+		return innerSummary, nil
+	}
+	innerVM.SetStateF = func(snow.State) error { return nil }
+
+	vm.hIndexer.MarkRepaired(true)
+	assert.NoError(vm.SetForkHeight(innerSummary.Height() - 1))
+	helperStorePostForkBlock(t, innerVM, vm, reqHeight, vm.ctx.ChainID)
+	helperStorePostForkBlock(t, innerVM, vm, reqHeight+1, vm.ctx.ChainID)
+
+	vm.Clock.Set(time.Now())
+	summary, err := vm.GetLastStateSummary()
+	assert.NoError(err)
+	assert.Equal(reqHeight, summary.Height())
+
+	// repairing the accepted chain drops the cached summary
+	assert.NoError(vm.repairAcceptedChainByHeight())
+	assert.Nil(vm.lastStateSummary)
+
+	_, err = vm.GetLastStateSummary()
+	assert.NoError(err)
+	assert.NotNil(vm.lastStateSummary)
+
+	// leaving state sync drops the cached summary, even within the refresh
+	// interval
+	assert.NoError(vm.SetState(snow.StateSyncing))
+	_, err = vm.GetLastStateSummary()
+	assert.NoError(err)
+	assert.NoError(vm.SetState(snow.Bootstrapping))
+
+	newInnerSummary := &block.TestStateSummary{
+		IDV:     ids.ID{'n', 'e', 'w', 'S', 'u', 'm', 'm', 'a', 'r', 'y', 'I', 'D'},
+		HeightV: reqHeight + 1,
+		BytesV:  []byte{'n', 'e', 'w'},
+	}
+	innerVM.GetLastStateSummaryF = func() (block.StateSummary, error) {
+		return newInnerSummary, nil
+	}
+	newSummary, err := vm.GetLastStateSummary()
+	assert.NoError(err)
+	assert.Equal(newInnerSummary.Height(), newSummary.Height())
+}
+
+func TestStateSyncLastStateSummaryMatchesStateSummaryAtHeight(t *testing.T) {
+	assert := assert.New(t)
+
+	innerVM, vm := helperBuildStateSyncTestObjects(t)
+	reqHeight := uint64(1969)
+
+	innerSummary := &block.TestStateSummary{
+		IDV:     ids.ID{'s', 'u', 'm', 'm', 'a', 'r', 'y', 'I', 'D'},
+		HeightV: reqHeight,
+		BytesV:  []byte{'i', 'n', 'n', 'e', 'r'},
+	}
+	innerVM.GetLastStateSummaryF = func() (block.StateSummary, error) {
+		return innerSummary, nil
+	}
+	innerVM.GetStateSummaryF = func(h uint64) (block.StateSummary, error) {
+		assert.True(h == reqHeight)
+		return innerSummary, nil
+	}
+
+	vm.hIndexer.MarkRepaired(true)
+	assert.NoError(vm.SetForkHeight(innerSummary.Height() - 1))
+
+	// store post fork block associated with summary
+	helperStorePostForkBlock(t, innerVM, vm, innerSummary.Height(), vm.ctx.ChainID)
+
+	// both the freshly built and the cached last summary must match the
+	// summary served at its height
+	for i := 0; i < 2; i++ {
+		lastSummary, err := vm.GetLastStateSummary()
+		assert.NoError(err)
+
+		summary, err := vm.GetStateSummary(lastSummary.Height())
+		assert.NoError(err)
+		assert.Equal(lastSummary.ID(), summary.ID())
+		assert.Equal(lastSummary.Height(), summary.Height())
+		assert.Equal(lastSummary.Bytes(), summary.Bytes())
+	}
+}
+
+func TestStateSyncSelfTest(t *testing.T) {
+	assert := assert.New(t)
+
+	innerVM, vm := helperBuildStateSyncTestObjects(t)
+	reqHeight := uint64(1969)
+
+	innerSummary := &block.TestStateSummary{
+		IDV:     ids.ID{'s', 'u', 'm', 'm', 'a', 'r', 'y', 'I', 'D'},
+		HeightV: reqHeight,
+		BytesV:  []byte{'i', 'n', 'n', 'e', 'r'},
+	}
+	innerVM.GetLastStateSummaryF = func() (block.StateSummary, error) {
+		return innerSummary, nil
+	}
+
+	// summaries match
+	innerVM.GetStateSummaryF = func(h uint64) (block.StateSummary, error) {
+		assert.True(h == reqHeight)
+		return innerSummary, nil
+	}
+	assert.NoError(vm.StateSyncSelfTest())
+
+	// diagnostics don't count as served summaries
+	_, _, err := vm.GetStateSummaryWithBlockID(reqHeight)
+	assert.NoError(err)
+	_, err = vm.GetStateSummaries([]uint64{reqHeight})
+	assert.NoError(err)
+	assert.Equal(float64(0), counterValue(t, vm.stateSyncMetrics.summariesServed))
+	_, err = vm.GetStateSummary(reqHeight)
+	assert.NoError(err)
+	assert.Equal(float64(1), counterValue(t, vm.stateSyncMetrics.summariesServed))
+
+	// summary at last summary height is missing
+	innerVM.GetStateSummaryF = func(h uint64) (block.StateSummary, error) {
+		return nil, database.ErrNotFound
+	}
+	err = vm.StateSyncSelfTest()
+	assert.ErrorIs(err, database.ErrNotFound)
+	assert.Contains(err.Error(), fmt.Sprint(reqHeight))
+
+	// summary at last summary height differs
+	innerVM.GetStateSummaryF = func(h uint64) (block.StateSummary, error) {
+		return &block.TestStateSummary{
+			IDV:     ids.ID{'o', 't', 'h', 'e', 'r', 'I', 'D'},
+			HeightV: h,
+			BytesV:  []byte{'o', 't', 'h', 'e', 'r'},
+		}, nil
+	}
+	assert.ErrorIs(vm.StateSyncSelfTest(), errSelfTestMismatch)
+
+	// no last summary
+	innerVM.GetLastStateSummaryF = func() (block.StateSummary, error) {
+		return nil, database.ErrNotFound
+	}
+	assert.ErrorIs(vm.StateSyncSelfTest(), database.ErrNotFound)
+}
+
 func TestStateSyncGetStateSummary(t *testing.T) {
 	assert := assert.New(t)
 
@@ -366,10 +779,17 @@ func TestStateSyncGetStateSummary(t *testing.T) {
 	summary, err = vm.GetStateSummary(reqHeight)
 	assert.NoError(err)
 	assert.True(summary.Height() == innerSummary.Height())
+
+	// the proposervm block ID is returned along with the summary
+	summary, blkID, err := vm.GetStateSummaryWithBlockID(reqHeight)
+	assert.NoError(err)
+	assert.True(summary.Height() == innerSummary.Height())
+	assert.Equal(proBlk.ID(), blkID)
 }
 
-func TestParseStateSummary(t *testing.T) {
+func TestStateSyncGetStateSummaryForkHeightFailure(t *testing.T) {
 	assert := assert.New(t)
+
 	innerVM, vm := helperBuildStateSyncTestObjects(t)
 	reqHeight := uint64(1969)
 
@@ -378,27 +798,210 @@ func TestParseStateSummary(t *testing.T) {
 		HeightV: reqHeight,
 		BytesV:  []byte{'i', 'n', 'n', 'e', 'r'},
 	}
-	innerVM.ParseStateSummaryF = func(summaryBytes []byte) (block.StateSummary, error) {
-		assert.True(bytes.Equal(summaryBytes, innerSummary.Bytes()))
-		return innerSummary, nil
-	}
 	innerVM.GetStateSummaryF = func(h uint64) (block.StateSummary, error) {
 		assert.True(h == reqHeight)
 		return innerSummary, nil
 	}
 
-	// Get a pre fork block than parse it
-	assert.NoError(vm.SetForkHeight(innerSummary.Height() + 1))
-	summary, err := vm.GetStateSummary(reqHeight)
-	assert.NoError(err)
+	vm.hIndexer.MarkRepaired(true)
+	errForkHeight := errors.New("fork height retrieval failed")
+	vm.State = &failingForkHeightState{
+		State: vm.State,
+		err:   errForkHeight,
+	}
 
-	parsedSummary, err := vm.ParseStateSummary(summary.Bytes())
-	assert.NoError(err)
-	assert.True(summary.ID() == parsedSummary.ID())
-	assert.True(summary.Height() == parsedSummary.Height())
-	assert.True(bytes.Equal(summary.Bytes(), parsedSummary.Bytes()))
+	_, err := vm.GetStateSummary(reqHeight)
+	assert.ErrorIs(err, errForkHeight)
+	assert.Contains(err.Error(), "fork height")
+}
+
+func TestStateSyncGetStateSummaryIndexMismatch(t *testing.T) {
+	assert := assert.New(t)
+
+	innerVM, vm := helperBuildStateSyncTestObjects(t)
+	reqHeight := uint64(1969)
+
+	innerSummary := &block.TestStateSummary{
+		IDV:     ids.ID{'s', 'u', 'm', 'm', 'a', 'r', 'y', 'I', 'D'},
+		HeightV: reqHeight,
+		BytesV:  []byte{'i', 'n', 'n', 'e', 'r'},
+	}
+	innerVM.GetStateSummaryF = func(h uint64) (block.StateSummary, error) {
+		assert.True(h == reqHeight)
+		return innerSummary, nil
+	}
 
-	// Get a post fork block than parse it
+	vm.hIndexer.MarkRepaired(true)
+	assert.NoError(vm.SetForkHeight(innerSummary.Height() - 1))
+
+	// store a post fork block at a different height than the summary
+	proBlk := helperStorePostForkBlock(t, innerVM, vm, reqHeight+1, vm.ctx.ChainID)
+
+	// corrupt the height index, mapping the summary height to that block
+	assert.NoError(vm.State.SetBlockIDAtHeight(reqHeight, proBlk.ID()))
+
+	_, err := vm.GetStateSummary(reqHeight)
+	assert.ErrorIs(err, errSummaryHeightMismatch)
+}
+
+func TestStateSyncGetStateSummaryEmptyBlockID(t *testing.T) {
+	assert := assert.New(t)
+
+	innerVM, vm := helperBuildStateSyncTestObjects(t)
+	reqHeight := uint64(1969)
+
+	innerSummary := &block.TestStateSummary{
+		IDV:     ids.ID{'s', 'u', 'm', 'm', 'a', 'r', 'y', 'I', 'D'},
+		HeightV: reqHeight,
+		BytesV:  []byte{'i', 'n', 'n', 'e', 'r'},
+	}
+	innerVM.GetStateSummaryF = func(h uint64) (block.StateSummary, error) {
+		assert.True(h == reqHeight)
+		return innerSummary, nil
+	}
+
+	vm.hIndexer.MarkRepaired(true)
+	assert.NoError(vm.SetForkHeight(innerSummary.Height() - 1))
+	vm.State = &emptyBlockIDState{
+		State: vm.State,
+	}
+
+	_, err := vm.GetStateSummary(reqHeight)
+	assert.ErrorIs(err, errEmptySummaryBlockID)
+}
+
+func TestStateSyncGetStateSummaryForkBoundary(t *testing.T) {
+	assert := assert.New(t)
+
+	innerVM, vm := helperBuildStateSyncTestObjects(t)
+	reqHeight := uint64(1969)
+
+	innerSummary := &block.TestStateSummary{
+		IDV:     ids.ID{'s', 'u', 'm', 'm', 'a', 'r', 'y', 'I', 'D'},
+		HeightV: reqHeight,
+		BytesV:  []byte{'i', 'n', 'n', 'e', 'r'},
+	}
+	innerVM.GetStateSummaryF = func(h uint64) (block.StateSummary, error) {
+		assert.True(h == reqHeight)
+		return innerSummary, nil
+	}
+	vm.hIndexer.MarkRepaired(true)
+
+	// summary height below fork height is served as the inner summary
+	assert.NoError(vm.SetForkHeight(reqHeight + 1))
+	summary, err := vm.GetStateSummary(reqHeight)
+	assert.NoError(err)
+	assert.Equal(innerSummary.ID(), summary.ID())
+	assert.Equal(innerSummary.Bytes(), summary.Bytes())
+	_, blkID, err := vm.GetStateSummaryWithBlockID(reqHeight)
+	assert.NoError(err)
+	assert.Equal(ids.Empty, blkID)
+
+	// summary height above fork height must be in the height index
+	assert.NoError(vm.SetForkHeight(reqHeight - 1))
+	_, err = vm.GetStateSummary(reqHeight)
+	assert.ErrorIs(err, database.ErrNotFound)
+
+	// summary height at fork height is wrapped in a post fork summary
+	assert.NoError(vm.SetForkHeight(reqHeight))
+	helperStorePostForkBlock(t, innerVM, vm, reqHeight, vm.ctx.ChainID)
+
+	summary, err = vm.GetStateSummary(reqHeight)
+	assert.NoError(err)
+	assert.Equal(reqHeight, summary.Height())
+	assert.NotEqual(innerSummary.ID(), summary.ID())
+	assert.IsType(&stateSummary{}, summary)
+}
+
+func TestStateSyncGetStateSummaryOnShutdown(t *testing.T) {
+	assert := assert.New(t)
+
+	innerVM, vm := helperBuildStateSyncTestObjects(t)
+	reqHeight := uint64(1969)
+
+	innerSummary := &block.TestStateSummary{
+		IDV:     ids.ID{'s', 'u', 'm', 'm', 'a', 'r', 'y', 'I', 'D'},
+		HeightV: reqHeight,
+		BytesV:  []byte{'i', 'n', 'n', 'e', 'r'},
+	}
+	innerVM.GetStateSummaryF = func(h uint64) (block.StateSummary, error) {
+		assert.True(h == reqHeight)
+		return innerSummary, nil
+	}
+
+	vm.hIndexer.MarkRepaired(true)
+	assert.NoError(vm.SetForkHeight(innerSummary.Height() - 1))
+
+	// summaries are not built once the VM context is cancelled
+	vm.onShutdown()
+	_, err := vm.GetStateSummary(reqHeight)
+	assert.ErrorIs(err, context.Canceled)
+}
+
+func TestStateSyncGetStateSummaries(t *testing.T) {
+	assert := assert.New(t)
+
+	innerVM, vm := helperBuildStateSyncTestObjects(t)
+	availableHeight := uint64(1969)
+	missingHeight := uint64(2022)
+
+	innerSummary := &block.TestStateSummary{
+		IDV:     ids.ID{'s', 'u', 'm', 'm', 'a', 'r', 'y', 'I', 'D'},
+		HeightV: availableHeight,
+		BytesV:  []byte{'i', 'n', 'n', 'e', 'r'},
+	}
+	innerVM.GetStateSummaryF = func(h uint64) (block.StateSummary, error) {
+		if h != availableHeight {
+			return nil, database.ErrNotFound
+		}
+		return innerSummary, nil
+	}
+
+	// all summaries available
+	summaries, err := vm.GetStateSummaries([]uint64{availableHeight})
+	assert.NoError(err)
+	assert.Len(summaries, 1)
+	assert.Equal(innerSummary.ID(), summaries[0].ID())
+
+	// partial result on missing summaries
+	summaries, err = vm.GetStateSummaries([]uint64{missingHeight, availableHeight})
+	assert.ErrorIs(err, database.ErrNotFound)
+	assert.Contains(err.Error(), fmt.Sprint(missingHeight))
+	assert.Len(summaries, 1)
+	assert.Equal(innerSummary.ID(), summaries[0].ID())
+}
+
+func TestParseStateSummary(t *testing.T) {
+	assert := assert.New(t)
+	innerVM, vm := helperBuildStateSyncTestObjects(t)
+	reqHeight := uint64(1969)
+
+	innerSummary := &block.TestStateSummary{
+		IDV:     ids.ID{'s', 'u', 'm', 'm', 'a', 'r', 'y', 'I', 'D'},
+		HeightV: reqHeight,
+		BytesV:  []byte{'i', 'n', 'n', 'e', 'r'},
+	}
+	innerVM.ParseStateSummaryF = func(summaryBytes []byte) (block.StateSummary, error) {
+		assert.True(bytes.Equal(summaryBytes, innerSummary.Bytes()))
+		return innerSummary, nil
+	}
+	innerVM.GetStateSummaryF = func(h uint64) (block.StateSummary, error) {
+		assert.True(h == reqHeight)
+		return innerSummary, nil
+	}
+
+	// Get a pre fork block than parse it
+	assert.NoError(vm.SetForkHeight(innerSummary.Height() + 1))
+	summary, err := vm.GetStateSummary(reqHeight)
+	assert.NoError(err)
+
+	parsedSummary, err := vm.ParseStateSummary(summary.Bytes())
+	assert.NoError(err)
+	assert.True(summary.ID() == parsedSummary.ID())
+	assert.True(summary.Height() == parsedSummary.Height())
+	assert.True(bytes.Equal(summary.Bytes(), parsedSummary.Bytes()))
+
+	// Get a post fork block than parse it
 	vm.hIndexer.MarkRepaired(true)
 	assert.NoError(vm.SetForkHeight(innerSummary.Height() - 1))
 
@@ -443,6 +1046,220 @@ func TestParseStateSummary(t *testing.T) {
 	assert.True(bytes.Equal(summary.Bytes(), parsedSummary.Bytes()))
 }
 
+func TestParseStateSummaryReportsBothErrors(t *testing.T) {
+	assert := assert.New(t)
+	innerVM, vm := helperBuildStateSyncTestObjects(t)
+
+	errInnerParse := errors.New("inner parse failed")
+	innerVM.ParseStateSummaryF = func(summaryBytes []byte) (block.StateSummary, error) {
+		return nil, errInnerParse
+	}
+
+	// bytes with an unknown codec version are tried as a pre-fork summary
+	_, err := vm.ParseStateSummary([]byte{0xff, 0xff, 2, 3, 4, 5})
+	assert.ErrorIs(err, errInnerParse)
+	assert.Contains(err.Error(), "post-fork")
+}
+
+func TestParseStateSummaryTruncated(t *testing.T) {
+	assert := assert.New(t)
+	innerVM, vm := helperBuildStateSyncTestObjects(t)
+
+	errInnerParse := errors.New("inner parse failed")
+	innerVM.ParseStateSummaryF = func(summaryBytes []byte) (block.StateSummary, error) {
+		return nil, errInnerParse
+	}
+
+	statelessSummary, err := summary.Build(1, []byte("block"), []byte("inner"))
+	assert.NoError(err)
+	summaryBytes := statelessSummary.Bytes()
+
+	_, err = vm.ParseStateSummary(summaryBytes[:len(summaryBytes)-1])
+	assert.ErrorIs(err, summary.ErrTruncatedSummary)
+	assert.ErrorIs(err, errInnerParse)
+}
+
+func TestParseStateSummaryPreForkWithCodecPrefix(t *testing.T) {
+	assert := assert.New(t)
+	innerVM, vm := helperBuildStateSyncTestObjects(t)
+
+	// a pre-fork summary marshalled by the inner VM with codec version 0:
+	// codec version | height | block hash | block root | atomic root
+	summaryBytes := []byte{0x00, 0x00}
+	summaryBytes = append(summaryBytes, 0, 0, 0, 0, 0, 0, 0x07, 0xb1)
+	for i := 0; i < 3; i++ {
+		hash := ids.GenerateTestID()
+		// the post-fork codec reads a length longer than the bytes left
+		hash[0], hash[1], hash[2], hash[3] = 0, 0, 1, 0
+		summaryBytes = append(summaryBytes, hash[:]...)
+	}
+	_, err := summary.Parse(summaryBytes)
+	assert.ErrorIs(err, summary.ErrTruncatedSummary)
+
+	innerSummary := &block.TestStateSummary{
+		IDV:     ids.ID{'s', 'u', 'm', 'm', 'a', 'r', 'y', 'I', 'D'},
+		HeightV: 1969,
+		BytesV:  summaryBytes,
+	}
+	innerVM.ParseStateSummaryF = func(b []byte) (block.StateSummary, error) {
+		assert.True(bytes.Equal(b, summaryBytes))
+		return innerSummary, nil
+	}
+
+	parsedSummary, err := vm.ParseStateSummary(summaryBytes)
+	assert.NoError(err)
+	assert.Equal(innerSummary, parsedSummary)
+}
+
+func TestParseStateSummaryHeightMismatch(t *testing.T) {
+	assert := assert.New(t)
+	innerVM, vm := helperBuildStateSyncTestObjects(t)
+	reqHeight := uint64(1969)
+
+	innerSummary := &block.TestStateSummary{
+		IDV:     ids.ID{'s', 'u', 'm', 'm', 'a', 'r', 'y', 'I', 'D'},
+		HeightV: reqHeight,
+		BytesV:  []byte{'i', 'n', 'n', 'e', 'r'},
+	}
+	innerVM.ParseStateSummaryF = func(summaryBytes []byte) (block.StateSummary, error) {
+		assert.True(bytes.Equal(summaryBytes, innerSummary.Bytes()))
+		return innerSummary, nil
+	}
+
+	// build a proposervm block at a different height than the inner summary
+	proBlk := helperStorePostForkBlock(t, innerVM, vm, reqHeight+1, vm.ctx.ChainID)
+
+	statelessSummary, err := summary.Build(reqHeight-1, proBlk.Bytes(), innerSummary.Bytes())
+	assert.NoError(err)
+
+	_, err = vm.ParseStateSummary(statelessSummary.Bytes())
+	assert.ErrorIs(err, errSummaryHeightMismatch)
+}
+
+func TestParseStateSummaryWrongChain(t *testing.T) {
+	assert := assert.New(t)
+	innerVM, vm := helperBuildStateSyncTestObjects(t)
+	reqHeight := uint64(1969)
+
+	innerSummary := &block.TestStateSummary{
+		IDV:     ids.ID{'s', 'u', 'm', 'm', 'a', 'r', 'y', 'I', 'D'},
+		HeightV: reqHeight,
+		BytesV:  []byte{'i', 'n', 'n', 'e', 'r'},
+	}
+	innerVM.ParseStateSummaryF = func(summaryBytes []byte) (block.StateSummary, error) {
+		assert.True(bytes.Equal(summaryBytes, innerSummary.Bytes()))
+		return innerSummary, nil
+	}
+
+	// build a proposervm block signed for another chain
+	proBlk := helperStorePostForkBlock(t, innerVM, vm, reqHeight, ids.GenerateTestID())
+
+	statelessSummary, err := summary.Build(reqHeight-1, proBlk.Bytes(), innerSummary.Bytes())
+	assert.NoError(err)
+
+	_, err = vm.ParseStateSummary(statelessSummary.Bytes())
+	assert.ErrorIs(err, errWrongChainSummary)
+}
+
+func TestHasStateSummary(t *testing.T) {
+	assert := assert.New(t)
+
+	innerVM, vm := helperBuildStateSyncTestObjects(t)
+	reqHeight := uint64(1969)
+
+	innerSummary := &block.TestStateSummary{
+		IDV:     ids.ID{'s', 'u', 'm', 'm', 'a', 'r', 'y', 'I', 'D'},
+		HeightV: reqHeight,
+		BytesV:  []byte{'i', 'n', 'n', 'e', 'r'},
+	}
+
+	// No state summary case
+	innerVM.GetStateSummaryF = func(h uint64) (block.StateSummary, error) {
+		return nil, database.ErrNotFound
+	}
+	available, err := vm.HasStateSummary(reqHeight)
+	assert.NoError(err)
+	assert.False(available)
+
+	// Pre fork summary case, fork height not reached hence not set yet
+	innerVM.GetStateSummaryF = func(h uint64) (block.StateSummary, error) {
+		assert.True(h == reqHeight)
+		return innerSummary, nil
+	}
+	vm.hIndexer.MarkRepaired(true)
+	available, err = vm.HasStateSummary(reqHeight)
+	assert.NoError(err)
+	assert.True(available)
+
+	// Post fork summary case, block not indexed
+	assert.NoError(vm.SetForkHeight(innerSummary.Height() - 1))
+	available, err = vm.HasStateSummary(reqHeight)
+	assert.NoError(err)
+	assert.False(available)
+
+	// Post fork summary case, block indexed but not stored
+	assert.NoError(vm.SetBlockIDAtHeight(reqHeight, ids.GenerateTestID()))
+	available, err = vm.HasStateSummary(reqHeight)
+	assert.NoError(err)
+	assert.False(available)
+
+	// Post fork summary case, block indexed and stored
+	helperStorePostForkBlock(t, innerVM, vm, reqHeight, vm.ctx.ChainID)
+	available, err = vm.HasStateSummary(reqHeight)
+	assert.NoError(err)
+	assert.True(available)
+	_, err = vm.GetStateSummary(reqHeight)
+	assert.NoError(err)
+
+	// availability is not checked once the VM context is cancelled
+	vm.onShutdown()
+	_, err = vm.HasStateSummary(reqHeight)
+	assert.ErrorIs(err, context.Canceled)
+}
+
+func TestCompareLastStateSummary(t *testing.T) {
+	assert := assert.New(t)
+	innerVM, vm := helperBuildStateSyncTestObjects(t)
+
+	localSummary := &block.TestStateSummary{
+		IDV:     ids.ID{'l', 'o', 'c', 'a', 'l'},
+		HeightV: uint64(2022),
+		BytesV:  []byte{'l', 'o', 'c', 'a', 'l'},
+	}
+	otherSummary := &block.TestStateSummary{
+		IDV:     ids.ID{'o', 't', 'h', 'e', 'r'},
+		HeightV: uint64(2021),
+		BytesV:  []byte{'o', 't', 'h', 'e', 'r'},
+	}
+	innerVM.GetLastStateSummaryF = func() (block.StateSummary, error) {
+		return localSummary, nil
+	}
+	innerVM.ParseStateSummaryF = func(summaryBytes []byte) (block.StateSummary, error) {
+		if bytes.Equal(summaryBytes, localSummary.Bytes()) {
+			return localSummary, nil
+		}
+		return otherSummary, nil
+	}
+
+	// pre fork summaries, fork height not reached yet
+	equal, reason, err := vm.CompareLastStateSummary(localSummary.Bytes())
+	assert.NoError(err)
+	assert.True(equal)
+	assert.Empty(reason)
+
+	equal, reason, err = vm.CompareLastStateSummary(otherSummary.Bytes())
+	assert.NoError(err)
+	assert.False(equal)
+	assert.Contains(reason, "height")
+
+	// same height, different summary
+	otherSummary.HeightV = localSummary.Height()
+	equal, reason, err = vm.CompareLastStateSummary(otherSummary.Bytes())
+	assert.NoError(err)
+	assert.False(equal)
+	assert.Contains(reason, "summary ID")
+}
+
 func TestStateSummaryAccept(t *testing.T) {
 	assert := assert.New(t)
 
@@ -507,6 +1324,14 @@ func TestStateSummaryAccept(t *testing.T) {
 	accepted, err = summary.Accept()
 	assert.NoError(err)
 	assert.False(accepted)
+
+	// replaying Accept must not error nor reach the inner summary again
+	innerSummary.AcceptF = nil
+	innerSummary.T = t
+	innerSummary.CantAccept = true
+	accepted, err = summary.Accept()
+	assert.NoError(err)
+	assert.False(accepted)
 }
 
 func TestStateSummaryAcceptOlderBlock(t *testing.T) {
@@ -573,6 +1398,121 @@ func TestStateSummaryAcceptOlderBlock(t *testing.T) {
 	assert.False(accepted)
 }
 
+func TestStateSyncMetrics(t *testing.T) {
+	assert := assert.New(t)
+
+	innerVM, vm, gatherer := helperBuildStateSyncTestObjectsWithMetrics(t)
+	reqHeight := uint64(1969)
+
+	// every unlabeled series is exported under the proposervm namespace
+	for _, name := range []string{
+		"proposervm_state_summaries_served",
+		"proposervm_state_summaries_accepted",
+		"proposervm_last_state_summary_height",
+		"proposervm_state_summary_lookup_count",
+		"proposervm_state_summary_lookup_sum",
+		"proposervm_state_summary_lookup_fork_not_found_count",
+		"proposervm_state_summary_lookup_fork_not_found_sum",
+	} {
+		value, found := gatheredValue(t, gatherer, name, nil)
+		assert.True(found, name)
+		assert.Zero(value, name)
+	}
+
+	innerSummary := &block.TestStateSummary{
+		IDV:     ids.ID{'s', 'u', 'm', 'm', 'a', 'r', 'y', 'I', 'D'},
+		HeightV: reqHeight,
+		BytesV:  []byte{'i', 'n', 'n', 'e', 'r'},
+		AcceptF: func() (bool, error) { return true, nil },
+	}
+	innerVM.GetStateSummaryF = func(uint64) (block.StateSummary, error) {
+		return innerSummary, nil
+	}
+	innerVM.ParseStateSummaryF = func([]byte) (block.StateSummary, error) {
+		return innerSummary, nil
+	}
+
+	// pre fork summary, the fork height is not set yet
+	vm.hIndexer.MarkRepaired(true)
+	_, err := vm.GetStateSummary(reqHeight)
+	assert.NoError(err)
+	value, _ := gatheredValue(t, gatherer, "proposervm_state_summary_lookup_fork_not_found_count", nil)
+	assert.Equal(float64(1), value)
+
+	// post fork summary
+	assert.NoError(vm.SetForkHeight(reqHeight - 1))
+	proBlk := helperStorePostForkBlock(t, innerVM, vm, reqHeight, vm.ctx.ChainID)
+	proSummary, err := vm.GetStateSummary(reqHeight)
+	assert.NoError(err)
+	value, _ = gatheredValue(t, gatherer, "proposervm_state_summary_lookup_count", nil)
+	assert.Equal(float64(1), value)
+	value, _ = gatheredValue(t, gatherer, "proposervm_state_summaries_served", nil)
+	assert.Equal(float64(2), value)
+
+	accepted, err := proSummary.Accept()
+	assert.NoError(err)
+	assert.True(accepted)
+	value, _ = gatheredValue(t, gatherer, "proposervm_state_summaries_accepted", nil)
+	assert.Equal(float64(1), value)
+
+	// parse failures are counted by reason
+	innerVM.ParseStateSummaryF = func([]byte) (block.StateSummary, error) {
+		return nil, errors.New("unknown summary")
+	}
+	_, err = vm.ParseStateSummary(proSummary.Bytes())
+	assert.Error(err)
+	value, found := gatheredValue(t, gatherer, "proposervm_state_summary_parse_failures", map[string]string{"reason": parseFailureInnerSummary})
+	assert.True(found)
+	assert.Equal(float64(1), value)
+
+	innerVM.ParseStateSummaryF = func([]byte) (block.StateSummary, error) {
+		return innerSummary, nil
+	}
+	mismatchedSummary, err := summary.Build(reqHeight-1, proBlk.Bytes(), []byte{'o', 't', 'h', 'e', 'r'})
+	assert.NoError(err)
+	innerSummary.HeightV = reqHeight + 1
+	_, err = vm.ParseStateSummary(mismatchedSummary.Bytes())
+	assert.ErrorIs(err, errSummaryHeightMismatch)
+	value, found = gatheredValue(t, gatherer, "proposervm_state_summary_parse_failures", map[string]string{"reason": parseFailureHeightMismatch})
+	assert.True(found)
+	assert.Equal(float64(1), value)
+
+	// the inner summary failure is not counted again
+	value, _ = gatheredValue(t, gatherer, "proposervm_state_summary_parse_failures", map[string]string{"reason": parseFailureInnerSummary})
+	assert.Equal(float64(1), value)
+}
+
+func TestHealthCheckWhileStateSyncing(t *testing.T) {
+	assert := assert.New(t)
+
+	innerVM, vm := helperBuildStateSyncTestObjects(t)
+
+	innerDetails := "inner details"
+	var innerErr error
+	innerVM.HealthCheckF = func() (interface{}, error) {
+		return innerDetails, innerErr
+	}
+	innerVM.SetStateF = func(snow.State) error { return nil }
+
+	// unhealthy while state syncing
+	assert.NoError(vm.SetState(snow.StateSyncing))
+	details, err := vm.HealthCheck()
+	assert.ErrorIs(err, errStateSyncing)
+	assert.Equal(innerDetails, details)
+
+	innerErr = errors.New("inner VM unhealthy")
+	_, err = vm.HealthCheck()
+	assert.ErrorIs(err, errStateSyncing)
+	assert.Contains(err.Error(), innerErr.Error())
+
+	// inner VM health is reported once state sync is done
+	innerErr = nil
+	assert.NoError(vm.SetState(snow.Bootstrapping))
+	details, err = vm.HealthCheck()
+	assert.NoError(err)
+	assert.Equal(innerDetails, details)
+}
+
 func TestNoStateSummariesServedWhileRepairingHeightIndex(t *testing.T) {
 	assert := assert.New(t)
 
@@ -5,8 +5,6 @@ package summary
 
 import (
 	"fmt"
-
-	"github.com/ava-labs/avalanchego/utils/hashing"
 )
 
 func Build(
@@ -20,12 +18,12 @@ func Build(
 		InnerSummary: coreSummary,
 	}
 
-	bytes, err := c.Marshal(codecVersion, &summary)
+	bytes, err := c.Marshal(CodecVersion, &summary)
 	if err != nil {
 		return nil, fmt.Errorf("cannot marshal proposer summary due to: %w", err)
 	}
 
-	summary.id = hashing.ComputeHash256Array(bytes)
+	summary.id = computeID(bytes)
 	summary.bytes = bytes
 	return &summary, nil
 }
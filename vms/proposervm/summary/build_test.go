@@ -22,3 +22,11 @@ func TestBuild(t *testing.T) {
 	assert.Equal(builtSummary.BlockBytes(), block)
 	assert.Equal(builtSummary.InnerSummaryBytes(), coreSummary)
 }
+
+func TestBuildOversizedSummary(t *testing.T) {
+	assert := assert.New(t)
+
+	block := make([]byte, maxSummarySize)
+	_, err := Build(2022, block, []byte("coreSummary"))
+	assert.Error(err)
+}
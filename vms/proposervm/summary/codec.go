@@ -5,24 +5,68 @@ package summary
 
 import (
 	"errors"
-	"math"
 
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/codec/reflectcodec"
+	"github.com/ava-labs/avalanchego/utils/constants"
 )
 
-const codecVersion = 0
+const (
+	// CodecVersion is the version used when marshalling new summaries.
+	CodecVersion = 0
+
+	// CodecTagName is the struct tag marking the serialized summary fields.
+	CodecTagName = reflectcodec.DefaultTagName
+)
 
 var (
+	// maxSummarySize is the largest summary that can be (un)marshalled.
+	// Summaries, including the proposervm block they carry, are exchanged in a
+	// single network message, so they can never be larger than one. Tests may
+	// lower it through setMaxSummarySize.
+	maxSummarySize = constants.DefaultMaxMessageSize
+
+	// ErrTruncatedSummary is returned when the summary bytes end before all of
+	// its fields could be parsed, so they may be requested again.
+	ErrTruncatedSummary = errors.New("truncated summary")
+
+	// supportedCodecVersions lists, in order, every codec version that
+	// summaries may be parsed with. Summaries are always built with
+	// [CodecVersion], which must be included.
+	supportedCodecVersions = []uint16{CodecVersion}
+
 	c codec.Manager
 
 	errWrongCodecVersion = errors.New("wrong codec version")
+	errOversizedSummary  = errors.New("oversized summary")
 )
 
 func init() {
-	lc := linearcodec.NewCustomMaxLength(math.MaxUint32)
-	c = codec.NewManager(math.MaxInt32)
-	if err := c.RegisterCodec(codecVersion, lc); err != nil {
-		panic(err)
+	setMaxSummarySize(maxSummarySize)
+}
+
+// setMaxSummarySize rebuilds the summary codec so that it (un)marshals
+// summaries of at most [size] bytes.
+func setMaxSummarySize(size int) {
+	manager := codec.NewManager(size)
+	for _, version := range supportedCodecVersions {
+		lc := linearcodec.New([]string{CodecTagName}, uint32(size))
+		if err := manager.RegisterCodec(version, lc); err != nil {
+			panic(err)
+		}
+	}
+	maxSummarySize = size
+	c = manager
+}
+
+// isSupportedCodecVersion returns true if summaries marshalled with [version]
+// can be parsed.
+func isSupportedCodecVersion(version uint16) bool {
+	for _, supported := range supportedCodecVersions {
+		if version == supported {
+			return true
+		}
 	}
+	return false
 }
@@ -4,22 +4,30 @@
 package summary
 
 import (
+	"errors"
 	"fmt"
 
-	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
 func Parse(bytes []byte) (StateSummary, error) {
+	if len(bytes) > maxSummarySize {
+		return nil, fmt.Errorf("%w: %d bytes, at most %d allowed", errOversizedSummary, len(bytes), maxSummarySize)
+	}
+
 	summary := stateSummary{
-		id:    hashing.ComputeHash256Array(bytes),
+		id:    computeID(bytes),
 		bytes: bytes,
 	}
 	version, err := c.Unmarshal(bytes, &summary)
+	if !isSupportedCodecVersion(version) {
+		return nil, fmt.Errorf("%w: got %d, expected one of %v", errWrongCodecVersion, version, supportedCodecVersions)
+	}
+	if errors.Is(err, wrappers.ErrInsufficientLength) {
+		return nil, fmt.Errorf("%w: %s", ErrTruncatedSummary, err)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("could not unmarshal summary due to: %w", err)
 	}
-	if version != codecVersion {
-		return nil, errWrongCodecVersion
-	}
 	return &summary, nil
 }
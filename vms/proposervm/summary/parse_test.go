@@ -4,9 +4,12 @@
 package summary
 
 import (
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
 func TestParse(t *testing.T) {
@@ -37,3 +40,80 @@ func TestParseGibberish(t *testing.T) {
 	_, err := Parse(bytes)
 	assert.Error(err)
 }
+
+func TestParseUnsupportedCodecVersion(t *testing.T) {
+	assert := assert.New(t)
+
+	builtSummary, err := Build(2022, []byte("blockBytes"), []byte("coreSummary"))
+	assert.NoError(err)
+
+	// overwrite the codec version prefix with an unregistered version
+	summaryBytes := append([]byte{}, builtSummary.Bytes()...)
+	summaryBytes[0] = 0xff
+	summaryBytes[1] = 0xff
+
+	_, err = Parse(summaryBytes)
+	assert.ErrorIs(err, errWrongCodecVersion)
+	assert.Contains(err.Error(), "got 65535")
+}
+
+func TestParseOversizedSummary(t *testing.T) {
+	assert := assert.New(t)
+
+	bytes := make([]byte, maxSummarySize+1)
+	_, err := Parse(bytes)
+	assert.ErrorIs(err, errOversizedSummary)
+}
+
+func TestParseLoweredMaxSummarySize(t *testing.T) {
+	assert := assert.New(t)
+
+	builtSummary, err := Build(2022, []byte("blockBytes"), []byte("coreSummary"))
+	assert.NoError(err)
+	summaryBytes := builtSummary.Bytes()
+
+	defer setMaxSummarySize(maxSummarySize)
+	setMaxSummarySize(len(summaryBytes) - 1)
+
+	_, err = Parse(summaryBytes)
+	assert.ErrorIs(err, errOversizedSummary)
+
+	_, err = Build(2022, []byte("blockBytes"), []byte("coreSummary"))
+	assert.Error(err)
+}
+
+func TestParseTruncatedAndRandomBytes(t *testing.T) {
+	assert := assert.New(t)
+
+	builtSummary, err := Build(2022, []byte("blockBytes"), []byte("coreSummary"))
+	assert.NoError(err)
+	summaryBytes := builtSummary.Bytes()
+
+	// every strict prefix of a valid summary must be rejected, as truncated
+	// once the codec version can be read
+	for i := 0; i < len(summaryBytes); i++ {
+		_, err := Parse(summaryBytes[:i])
+		assert.Error(err)
+		if i >= wrappers.ShortLen {
+			assert.ErrorIs(err, ErrTruncatedSummary)
+		}
+	}
+
+	// arbitrary bytes must never cause a panic, and any content parsed out of
+	// them must fit within them
+	r := rand.New(rand.NewSource(0)) // #nosec G404
+	for i := 0; i < 1000; i++ {
+		bytes := make([]byte, r.Intn(2*len(summaryBytes)))
+		_, _ = r.Read(bytes)
+		if r.Intn(2) == 0 && len(bytes) >= 2 {
+			// keep a valid codec version to reach the field decoding
+			bytes[0], bytes[1] = 0, 0
+		}
+
+		parsedSummary, err := Parse(bytes)
+		if err != nil {
+			continue
+		}
+		assert.LessOrEqual(len(parsedSummary.BlockBytes())+len(parsedSummary.InnerSummaryBytes()), len(bytes))
+	}
+}
@@ -5,6 +5,7 @@ package summary
 
 import (
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
 )
 
 var _ StateSummary = &stateSummary{}
@@ -35,3 +36,9 @@ func (s *stateSummary) ForkHeight() uint64        { return s.Height }
 func (s *stateSummary) BlockBytes() []byte        { return s.Block }
 func (s *stateSummary) InnerSummaryBytes() []byte { return s.InnerSummary }
 func (s *stateSummary) Bytes() []byte             { return s.bytes }
+
+// computeID returns the canonical identifier of the summary serialized as
+// [bytes]. Built and parsed summaries must be identified the same way.
+func computeID(bytes []byte) ids.ID {
+	return hashing.ComputeHash256Array(bytes)
+}
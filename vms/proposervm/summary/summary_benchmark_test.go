@@ -0,0 +1,51 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package summary
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+var benchmarkSummarySizes = []int{
+	1 * units.KiB,   // 1kb
+	16 * units.KiB,  // 16kb
+	256 * units.KiB, // 256kb
+	1 * units.MiB,   // 1mb
+}
+
+func BenchmarkBuild(b *testing.B) {
+	for _, size := range benchmarkSummarySizes {
+		block := make([]byte, size/2)
+		coreSummary := make([]byte, size/2)
+		b.Run(fmt.Sprintf("%d bytes", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				if _, err := Build(2022, block, coreSummary); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	for _, size := range benchmarkSummarySizes {
+		builtSummary, err := Build(2022, make([]byte, size/2), make([]byte, size/2))
+		if err != nil {
+			b.Fatal(err)
+		}
+		summaryBytes := builtSummary.Bytes()
+		b.Run(fmt.Sprintf("%d bytes", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				if _, err := Parse(summaryBytes); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
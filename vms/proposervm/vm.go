@@ -5,9 +5,13 @@ package proposervm
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/manager"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
@@ -44,6 +48,8 @@ var (
 	_ block.StateSyncableVM      = &VM{}
 
 	dbPrefix = []byte("proposervm")
+
+	errNilChainVM = errors.New("proposervm requires a non-nil inner chain VM")
 )
 
 type VM struct {
@@ -68,6 +74,18 @@ type VM struct {
 	db          *versiondb.Database
 	toScheduler chan<- common.Message
 
+	stateSyncMetrics stateSyncMetrics
+
+	// lastStateSummary caches the last post-fork summary built by
+	// GetLastStateSummary, so that it is not rebuilt until the inner VM reports
+	// a new one.
+	lastStateSummary *stateSummary
+	// lastStateSummaryTime is the time [lastStateSummary] was built at. Within
+	// [lastStateSummaryInterval] of it, the cached summary is served without
+	// querying the inner VM.
+	lastStateSummaryTime     time.Time
+	lastStateSummaryInterval time.Duration
+
 	// Block ID --> Block
 	// Each element is a block that passed verification but
 	// hasn't yet been accepted/rejected
@@ -90,6 +108,7 @@ func New(
 	vm block.ChainVM,
 	activationTime time.Time,
 	minimumPChainHeight uint64,
+	lastStateSummaryInterval time.Duration,
 ) *VM {
 	bVM, _ := vm.(block.BatchedChainVM)
 	hVM, _ := vm.(block.HeightIndexedChainVM)
@@ -102,6 +121,8 @@ func New(
 
 		activationTime:      activationTime,
 		minimumPChainHeight: minimumPChainHeight,
+
+		lastStateSummaryInterval: lastStateSummaryInterval,
 	}
 }
 
@@ -115,6 +136,28 @@ func (vm *VM) Initialize(
 	fxs []*common.Fx,
 	appSender common.AppSender,
 ) error {
+	if vm.ChainVM == nil {
+		return errNilChainVM
+	}
+
+	registerer := prometheus.NewRegistry()
+	if err := vm.stateSyncMetrics.Initialize("", registerer); err != nil {
+		return err
+	}
+
+	optionalGatherer := metrics.NewOptionalGatherer()
+	multiGatherer := metrics.NewMultiGatherer()
+	if err := multiGatherer.Register("proposervm", registerer); err != nil {
+		return err
+	}
+	if err := multiGatherer.Register("", optionalGatherer); err != nil {
+		return err
+	}
+	if err := ctx.Metrics.Register(multiGatherer); err != nil {
+		return err
+	}
+	ctx.Metrics = optionalGatherer
+
 	vm.ctx = ctx
 	rawDB := dbManager.Current().Database
 	prefixDB := prefixdb.New(dbPrefix, rawDB)
@@ -390,6 +433,10 @@ func (vm *VM) repair(indexerState state.State) error {
 }
 
 func (vm *VM) repairAcceptedChainByIteration() error {
+	// the last accepted block may be rolled back, so the cached last state
+	// summary can't be trusted anymore
+	vm.lastStateSummary = nil
+
 	lastAcceptedID, err := vm.GetLastAccepted()
 	if err == database.ErrNotFound {
 		// If the last accepted block isn't indexed yet, then the underlying
@@ -460,6 +507,10 @@ func (vm *VM) repairAcceptedChainByIteration() error {
 }
 
 func (vm *VM) repairAcceptedChainByHeight() error {
+	// the last accepted block, the height index and the fork height may be
+	// rolled back, so the cached last state summary can't be trusted anymore
+	vm.lastStateSummary = nil
+
 	innerLastAcceptedID, err := vm.ChainVM.LastAccepted()
 	if err != nil {
 		return err
@@ -122,7 +122,7 @@ func initTestProposerVM(
 		}
 	}
 
-	proVM := New(coreVM, proBlkStartTime, minPChainHeight)
+	proVM := New(coreVM, proBlkStartTime, minPChainHeight, 0)
 
 	valState := &validators.TestState{
 		T: t,
@@ -861,7 +861,7 @@ func TestExpiredBuildBlock(t *testing.T) {
 		}
 	}
 
-	proVM := New(coreVM, time.Time{}, 0)
+	proVM := New(coreVM, time.Time{}, 0, 0)
 
 	valState := &validators.TestState{
 		T: t,
@@ -1191,7 +1191,7 @@ func TestInnerVMRollback(t *testing.T) {
 
 	dbManager := manager.NewMemDB(version.DefaultVersion1_0_0)
 
-	proVM := New(coreVM, time.Time{}, 0)
+	proVM := New(coreVM, time.Time{}, 0, 0)
 
 	if err := proVM.Initialize(ctx, dbManager, nil, nil, nil, nil, nil, nil); err != nil {
 		t.Fatalf("failed to initialize proposerVM with %s", err)
@@ -1282,7 +1282,7 @@ func TestInnerVMRollback(t *testing.T) {
 
 	coreBlk.StatusV = choices.Processing
 
-	proVM = New(coreVM, time.Time{}, 0)
+	proVM = New(coreVM, time.Time{}, 0, 0)
 
 	if err := proVM.Initialize(ctx, dbManager, nil, nil, nil, nil, nil, nil); err != nil {
 		t.Fatalf("failed to initialize proposerVM with %s", err)
@@ -1806,7 +1806,7 @@ func TestRejectedHeightNotIndexed(t *testing.T) {
 		}
 	}
 
-	proVM := New(coreVM, time.Time{}, 0)
+	proVM := New(coreVM, time.Time{}, 0, 0)
 
 	valState := &validators.TestState{
 		T: t,
@@ -1984,7 +1984,7 @@ func TestRejectedOptionHeightNotIndexed(t *testing.T) {
 		}
 	}
 
-	proVM := New(coreVM, time.Time{}, 0)
+	proVM := New(coreVM, time.Time{}, 0, 0)
 
 	valState := &validators.TestState{
 		T: t,